@@ -0,0 +1,9 @@
+package ratings
+
+import "errors"
+
+// ErrRatingNotFound is returned when no rating exists for the requested talk UUID.
+var ErrRatingNotFound = errors.New("ratings: rating not found")
+
+// ErrRepositoryUnavailable is returned when the repository could not reach Redis.
+var ErrRepositoryUnavailable = errors.New("ratings: repository unavailable")
@@ -2,13 +2,23 @@ package ratings
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/testcontainers/workshop-go/internal/resilience"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultResetTimeout     = 30 * time.Second
 )
 
 // Repository is the interface that wraps the basic operations with the Redis store.
 type Repository struct {
 	client *redis.Client
+	guard  *resilience.Guard
 }
 
 // NewRepository creates a new repository. It will receive a context and the Redis connection string.
@@ -23,28 +33,71 @@ func NewRepository(ctx context.Context, connStr string) (*Repository, error) {
 
 	cli := redis.NewClient(options)
 
-	pong, err := cli.Ping(ctx).Result()
+	guard := resilience.NewGuard(
+		resilience.DefaultBackoffPolicy(),
+		resilience.NewCircuitBreaker(defaultFailureThreshold, defaultResetTimeout),
+	)
+
+	err = guard.Do(ctx, func() error {
+		pong, err := cli.Ping(ctx).Result()
+		if err != nil {
+			return err
+		}
+
+		if pong != "PONG" {
+			return fmt.Errorf("ratings: unexpected PING reply %q", pong)
+		}
+
+		return nil
+	})
 	if err != nil {
-		// You probably want to retry here
-		return nil, err
+		return nil, fmt.Errorf("ratings: connect: %w: %w", ErrRepositoryUnavailable, err)
 	}
 
-	if pong != "PONG" {
-		// You probably want to retry here
-		return nil, err
+	return &Repository{client: cli, guard: guard}, nil
+}
+
+// Add adds a new rating for a talk identified by its UUID to the Redis store.
+func (r *Repository) Add(ctx context.Context, rating Rating) error {
+	err := r.guard.Do(ctx, func() error {
+		return r.client.IncrBy(ctx, toKey(rating.TalkUuid), rating.Value).Err()
+	})
+	if err != nil {
+		return fmt.Errorf("ratings: add %+v: %w", rating, err)
 	}
 
-	return &Repository{client: cli}, nil
+	return nil
 }
 
-// Add adds a new rating for a talk identified by its UUID to the Redis store.
-func (r *Repository) Add(ctx context.Context, rating Rating) {
-	_ = r.client.IncrBy(ctx, toKey(rating.TalkUuid), rating.Value).Val()
+// Get retrieves a rating for a talk identified by its UUID from the Redis
+// store. It returns ErrRatingNotFound, wrapped, when no rating exists for uid.
+func (r *Repository) Get(ctx context.Context, uid string) (string, error) {
+	var result string
+
+	err := r.guard.Do(ctx, func() error {
+		cmd := r.client.Get(ctx, toKey(uid))
+		if err := cmd.Err(); err != nil {
+			return err
+		}
+
+		result = cmd.Val()
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", fmt.Errorf("ratings: get %q: %w", uid, ErrRatingNotFound)
+		}
+
+		return "", fmt.Errorf("ratings: get %q: %w", uid, err)
+	}
+
+	return result, nil
 }
 
-// Get retrieves a rating for a talk identified by its UUID from the Redis store.
-func (r *Repository) Get(ctx context.Context, uid string) string {
-	return r.client.Get(ctx, toKey(uid)).Val()
+// Stats returns the retry/circuit-breaker metrics accumulated by this
+// repository's calls to Redis: attempts, failures, and breaker state.
+func (r *Repository) Stats() resilience.Stats {
+	return r.guard.Stats()
 }
 
 // toKey is a helper function that returns the uuid prefixed with "ratings/".
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/testcontainers/workshop-go/internal/ratings"
+	"github.com/testcontainers/workshop-go/internal/ratings/keywatcher"
+	"github.com/testcontainers/workshop-go/internal/streams"
+	"github.com/testcontainers/workshop-go/internal/talks"
+)
+
+// App holds the repositories backing the HTTP handlers, each connected once
+// at startup and reused across requests instead of being dialed per request.
+type App struct {
+	Talks   *talks.Repository
+	Streams *streams.Repository
+
+	// ratingsRepo is unexported because the Ratings handler method already
+	// claims that name on *App; a field and a method cannot share it.
+	ratingsRepo *ratings.Repository
+
+	// stats is built once from the STATS_BACKEND environment variable and
+	// reused across requests, so its retry/circuit-breaker state (when
+	// backed by the Lambda client) actually accumulates instead of being
+	// thrown away after every call.
+	stats ratings.StatsComputer
+
+	// ratingsWatcher backs RatingsStream/RatingsStats. It is built once here,
+	// rather than lazily behind a package-level sync.Once, so that each App
+	// gets its own watcher wired to its own Ratings connection instead of
+	// every App in the process sharing (and leaking) the first one built.
+	ratingsWatcher *keywatcher.Watcher
+
+	consumer *streams.Consumer
+}
+
+// NewApp connects the Talks, Ratings and Streams repositories using the
+// connection strings in Connections, starts the background consumer that
+// materializes RatingsTopic into the Ratings repository, and returns an App
+// ready to be passed to SetupApp. Callers are responsible for calling Close
+// once the App is no longer needed.
+func NewApp(ctx context.Context) (*App, error) {
+	talksRepo, err := talks.NewRepository(ctx, Connections.Talks)
+	if err != nil {
+		return nil, fmt.Errorf("app: new talks repository: %w", err)
+	}
+
+	ratingsRepo, err := ratings.NewRepositoryWithOptions(ctx, RatingsOptions())
+	if err != nil {
+		return nil, fmt.Errorf("app: new ratings repository: %w", err)
+	}
+
+	streamsRepo, err := streams.NewStream(ctx, Connections.Streams)
+	if err != nil {
+		return nil, fmt.Errorf("app: new streams repository: %w", err)
+	}
+
+	consumer, err := streams.NewConsumer(Connections.Streams, streams.DefaultConsumerGroup, ratingsRepo)
+	if err != nil {
+		return nil, fmt.Errorf("app: new streams consumer: %w", err)
+	}
+	consumer.Start(ctx)
+
+	ratingsWatcher, err := keywatcher.New(ctx, Connections.Ratings)
+	if err != nil {
+		return nil, fmt.Errorf("app: new ratings watcher: %w", err)
+	}
+
+	return &App{
+		Talks:          talksRepo,
+		ratingsRepo:    ratingsRepo,
+		Streams:        streamsRepo,
+		stats:          newStatsComputer(),
+		ratingsWatcher: ratingsWatcher,
+		consumer:       consumer,
+	}, nil
+}
+
+// Close tears down every repository connection, continuing past the first
+// error so that a failure to close one repository does not leak the others.
+func (a *App) Close(ctx context.Context) error {
+	a.consumer.Stop()
+
+	var errs []error
+
+	if err := a.Talks.Close(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("app: close talks repository: %w", err))
+	}
+	if err := a.ratingsRepo.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("app: close ratings repository: %w", err))
+	}
+	if err := a.ratingsWatcher.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("app: close ratings watcher: %w", err))
+	}
+	a.Streams.Close()
+
+	return errors.Join(errs...)
+}
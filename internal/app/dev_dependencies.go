@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	osexec "os/exec"
 	"path/filepath"
 	"runtime"
@@ -20,9 +21,16 @@ import (
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/modules/redis"
 	"github.com/testcontainers/testcontainers-go/modules/redpanda"
+	"github.com/testcontainers/testcontainers-go/network"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/testcontainers/workshop-go/internal/ratings"
 )
 
+// ratingsTopologyEnvVar selects the Redis topology used by startRatingsStore
+// in dev mode. Set it to "sentinel" to exercise the Sentinel-backed
+// NewRepositoryFromOptions path instead of the default standalone instance.
+const ratingsTopologyEnvVar = "WORKSHOP_RATINGS_TOPOLOGY"
+
 // init will be used to start up the containers for development mode. It will use
 // testcontainers-go to start up the following containers:
 // - Postgres: store for talks
@@ -180,6 +188,10 @@ func startRatingsLambda() (testcontainers.Container, error) {
 }
 
 func startRatingsStore() (testcontainers.Container, error) {
+	if strings.EqualFold(os.Getenv(ratingsTopologyEnvVar), "sentinel") {
+		return startRatingsStoreSentinel()
+	}
+
 	ctx := context.Background()
 
 	c, err := redis.Run(ctx, "redis:6-alpine")
@@ -196,6 +208,95 @@ func startRatingsStore() (testcontainers.Container, error) {
 	return c, nil
 }
 
+// startRatingsStoreSentinel starts a master Redis node plus a Sentinel node
+// watching it, and points the app at it through the Sentinel topology
+// (RATINGS_SENTINEL_ADDRS/RATINGS_SENTINEL_MASTER, read by RatingsOptions),
+// so that NewApp connects via ratings.NewRepositoryFromOptions' Sentinel path
+// instead of the plain master connection string, exercising it end-to-end.
+// See internal/ratings/failover_test.go for the same path exercised directly.
+func startRatingsStoreSentinel() (testcontainers.Container, error) {
+	ctx := context.Background()
+
+	net, err := network.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	const masterAlias = "ratings-master"
+	const sentinelMaster = "mymaster"
+
+	masterC, err := redis.Run(ctx, "redis:7",
+		network.WithNetwork([]string{masterAlias}, net),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sentinelConf := fmt.Sprintf(
+		"port 26379\nsentinel monitor %s %s 6379 1\nsentinel down-after-milliseconds %s 5000\nsentinel failover-timeout %s 10000\nsentinel parallel-syncs %s 1\n",
+		sentinelMaster, masterAlias, sentinelMaster, sentinelMaster, sentinelMaster,
+	)
+
+	sentinelC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7",
+			ExposedPorts: []string{"26379/tcp"},
+			Networks:     []string{net.Name},
+			Files: []testcontainers.ContainerFile{
+				{
+					Reader:            strings.NewReader(sentinelConf),
+					ContainerFilePath: "/usr/local/etc/redis/sentinel.conf",
+					FileMode:          0o644,
+				},
+			},
+			Cmd:        []string{"redis-server", "/usr/local/etc/redis/sentinel.conf", "--sentinel"},
+			WaitingFor: wait.ForLog("+monitor master " + sentinelMaster),
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sentinelHost, err := sentinelC.Host(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sentinelPort, err := sentinelC.MappedPort(ctx, "26379/tcp")
+	if err != nil {
+		return nil, err
+	}
+
+	sentinelAddr := fmt.Sprintf("%s:%s", sentinelHost, sentinelPort.Port())
+
+	os.Setenv("RATINGS_SENTINEL_ADDRS", sentinelAddr)
+	os.Setenv("RATINGS_SENTINEL_MASTER", sentinelMaster)
+
+	masterConn, err := masterC.ConnectionString(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Connections.Ratings keeps the master's plain connection string: with
+	// RATINGS_SENTINEL_ADDRS set above, RatingsOptions prefers the Sentinel
+	// config for the ratings.Repository itself, but the keyspace watcher
+	// behind /ratings/stream (internal/ratings/keywatcher.New) only knows how
+	// to dial a plain URL, so it still needs a direct route to the master.
+	Connections.Ratings = masterConn
+
+	// Smoke-test the Sentinel path right away, so a misconfiguration (e.g. a
+	// sentinel.conf typo) fails fast at startup instead of on the first
+	// request.
+	smokeRepo, err := ratings.NewRepositoryFromOptions(ctx, RatingsOptions())
+	if err != nil {
+		return nil, fmt.Errorf("smoke test ratings repository via sentinel: %w", err)
+	}
+	defer smokeRepo.Close()
+
+	return sentinelC, nil
+}
+
 func startStreamingQueue() (testcontainers.Container, error) {
 	ctx := context.Background()
 
@@ -1,19 +1,29 @@
 package app
 
 import (
-	"encoding/json"
 	"errors"
-	"fmt"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/testcontainers/workshop-go/internal/ratings"
-	"github.com/testcontainers/workshop-go/internal/streams"
 	"github.com/testcontainers/workshop-go/internal/talks"
 )
 
-func Root(c *fiber.Ctx) error {
+// newStatsComputer returns the ratings.StatsComputer selected by the
+// STATS_BACKEND environment variable, defaulting to the Lambda function. It
+// is called once by NewApp; handlers reuse the resulting App.stats instead of
+// each building their own.
+func newStatsComputer() ratings.StatsComputer {
+	if strings.EqualFold(StatsBackend, "local") {
+		return ratings.LocalStatsComputer{}
+	}
+
+	return ratings.NewLambdaClient(Connections.Lambda)
+}
+
+func (a *App) Root(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{
 		"metadata": Connections,
 	})
@@ -36,32 +46,19 @@ type ratingForPost struct {
 //	}
 //
 // If the talk with the given UUID exists in the Talks repository, it will send the rating
-// to the Streams repository, which will send it to the broker. If the talk does not exist,
-// or any of the repositories cannot be created, it will return an error.
-func AddRating(c *fiber.Ctx) error {
+// to the Streams repository, which will send it to the broker. The Ratings repository
+// is updated asynchronously by streams.Consumer once the rating is read back off the
+// topic, so this handler only needs to produce. If the talk does not exist, it will
+// return an error.
+func (a *App) AddRating(c *fiber.Ctx) error {
 	var r ratingForPost
 
 	if err := c.BodyParser(&r); err != nil {
 		return handleError(c, http.StatusInternalServerError, err)
 	}
 
-	talksRepo, err := talks.NewRepository(c.Context(), Connections.Talks)
-	if err != nil {
-		return handleError(c, http.StatusInternalServerError, err)
-	}
-
-	if !talksRepo.Exists(c.Context(), r.UUID) {
-		return handleError(c, http.StatusNotFound, fmt.Errorf("talk with UUID %s does not exist", r.UUID))
-	}
-
-	streamsRepo, err := streams.NewStream(c.Context(), Connections.Streams)
-	if err != nil {
-		return handleError(c, http.StatusInternalServerError, err)
-	}
-
-	ratingsRepo, err := ratings.NewRepository(c.Context(), Connections.Ratings)
-	if err != nil {
-		return handleError(c, http.StatusInternalServerError, err)
+	if _, err := a.Talks.GetByUUID(c.Context(), r.UUID); err != nil {
+		return handleRepositoryError(c, err)
 	}
 
 	rating := ratings.Rating{
@@ -69,14 +66,11 @@ func AddRating(c *fiber.Ctx) error {
 		Value:    r.Rating,
 	}
 
-	ratingsCallback := func() error {
-		_, err := ratingsRepo.Add(c.Context(), rating)
-		return err
-	}
+	noopCallback := func() error { return nil }
 
-	err = streamsRepo.SendRating(c.Context(), rating, ratingsCallback)
+	err := a.Streams.SendRating(c.Context(), rating, noopCallback)
 	if err != nil {
-		return handleError(c, http.StatusInternalServerError, err)
+		return handleRepositoryError(c, err)
 	}
 
 	return c.Status(http.StatusOK).JSON(fiber.Map{
@@ -90,14 +84,9 @@ type talkForRatings struct {
 	UUID string `json:"talkId" form:"talkId" binding:"required"`
 }
 
-type statsResponse struct {
-	Avg        float64 `json:"avg"`
-	TotalCount int64   `json:"totalCount"`
-}
-
 // Ratings is the handler for the `GET /ratings?talkId=xxx` endpoint. It will require a talkId parameter
 // in the query string and will return all the ratings for the given talk UUID.
-func Ratings(c *fiber.Ctx) error {
+func (a *App) Ratings(c *fiber.Ctx) error {
 	talkID := c.Query("talkId", "")
 	if talkID == "" {
 		return handleError(c, http.StatusInternalServerError, errors.New("talkId is required"))
@@ -105,38 +94,19 @@ func Ratings(c *fiber.Ctx) error {
 
 	talk := talkForRatings{UUID: talkID}
 
-	talksRepo, err := talks.NewRepository(c.Context(), Connections.Talks)
-	if err != nil {
-		return handleError(c, http.StatusInternalServerError, err)
-	}
-
-	if !talksRepo.Exists(c.Context(), talk.UUID) {
-		return handleError(c, http.StatusNotFound, fmt.Errorf("talk with UUID %s does not exist", talk.UUID))
-	}
-
-	ratingsRepo, err := ratings.NewRepository(c.Context(), Connections.Ratings)
-	if err != nil {
-		return handleError(c, http.StatusInternalServerError, err)
+	if _, err := a.Talks.GetByUUID(c.Context(), talk.UUID); err != nil {
+		return handleRepositoryError(c, err)
 	}
 
-	histogram := ratingsRepo.FindAllByUUID(c.Context(), talk.UUID)
-
-	// call the lambda function to get the stats
-	lambdaClient := ratings.NewLambdaClient(Connections.Lambda)
-	stats, err := lambdaClient.GetStats(histogram)
-	if err != nil {
-		// do not fail if the lambda function is not available, simply do not aggregate the stats
-		log.Printf("error calling lambda function: %s", err.Error())
-		return c.Status(http.StatusOK).JSON(fiber.Map{
-			"ratings": histogram,
-		})
+	histogram, err := a.ratingsRepo.FindAllByUUID(c.Context(), talk.UUID)
+	if err != nil && !errors.Is(err, ratings.ErrRatingNotFound) {
+		return handleRepositoryError(c, err)
 	}
 
-	statsResp := &statsResponse{}
-	err = json.Unmarshal(stats, statsResp)
+	stats, err := a.stats.GetStats(histogram)
 	if err != nil {
-		// do not fail if the lambda function is not available, simply do not aggregate the stats
-		log.Printf("error unmarshalling lambda response: %s", err.Error())
+		// do not fail if the stats backend is not available, simply do not aggregate the stats
+		log.Printf("error computing stats: %s", err.Error())
 		return c.Status(http.StatusOK).JSON(fiber.Map{
 			"ratings": histogram,
 		})
@@ -144,7 +114,7 @@ func Ratings(c *fiber.Ctx) error {
 
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"ratings": histogram,
-		"stats":   statsResp,
+		"stats":   stats,
 	})
 }
 
@@ -153,3 +123,18 @@ func handleError(c *fiber.Ctx, code int, err error) error {
 		"message": err.Error(),
 	})
 }
+
+// handleRepositoryError maps a repository error to an HTTP response, translating
+// the typed sentinel errors from internal/talks and internal/ratings into the
+// appropriate status code: 404 when the requested resource does not exist, 503
+// when the underlying store could not be reached, and 500 otherwise.
+func handleRepositoryError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, talks.ErrTalkNotFound), errors.Is(err, ratings.ErrRatingNotFound):
+		return handleError(c, http.StatusNotFound, err)
+	case errors.Is(err, talks.ErrRepositoryUnavailable), errors.Is(err, ratings.ErrRepositoryUnavailable):
+		return handleError(c, http.StatusServiceUnavailable, err)
+	default:
+		return handleError(c, http.StatusInternalServerError, err)
+	}
+}
@@ -1,18 +1,72 @@
 package app
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"strings"
 
-// The connection string for each of the services needed by the application.
-// The application will need them to connect to services, reading it from
-// the right environment variable in production, or from the container in development.
-type connections struct {
+	"github.com/testcontainers/workshop-go/internal/ratings"
+)
+
+// Metadata holds the connection string for each of the services needed by
+// the application. The application will need them to connect to services,
+// reading it from the right environment variable in production, or from the
+// container in development.
+type Metadata struct {
 	Ratings string `json:"ratings"` // Read from the RATINGS_CONNECTION environment variable
 	Streams string `json:"streams"` // Read from the STREAMS_CONNECTION environment variable
 	Talks   string `json:"talks"`   // Read from the TALKS_CONNECTION environment variable
+	Lambda  string `json:"lambda"`  // Read from the LAMBDA_CONNECTION environment variable
 }
 
-var Connections *connections = &connections{
+var Connections *Metadata = &Metadata{
 	Ratings: os.Getenv("RATINGS_CONNECTION"),
 	Streams: os.Getenv("STREAMS_CONNECTION"),
 	Talks:   os.Getenv("TALKS_CONNECTION"),
+	Lambda:  os.Getenv("LAMBDA_CONNECTION"),
+}
+
+// StatsBackend selects which ratings.StatsComputer implementation the app
+// uses to aggregate a talk's rating histogram: "lambda" (the default) calls
+// out to the Lambda function, "local" computes the same stats in-process.
+// Read from the STATS_BACKEND environment variable.
+var StatsBackend = os.Getenv("STATS_BACKEND")
+
+// RatingsOptions builds the ratings.RepositoryOptions used to connect to the
+// ratings store, reading the Sentinel configuration from the RATINGS_SENTINEL_*
+// environment variables when set. When RATINGS_SENTINEL_ADDRS is empty, the
+// returned options describe a standalone connection using Connections.Ratings,
+// matching local/testcontainer development.
+func RatingsOptions() ratings.RepositoryOptions {
+	opts := ratings.RepositoryOptions{
+		URL:              Connections.Ratings,
+		Password:         os.Getenv("RATINGS_PASSWORD"),
+		DB:               envInt("RATINGS_DB", 0),
+		PoolSize:         envInt("RATINGS_POOL_SIZE", 0),
+		MinIdleConns:     envInt("RATINGS_MIN_IDLE_CONNS", 0),
+		SentinelMaster:   os.Getenv("RATINGS_SENTINEL_MASTER"),
+		SentinelPassword: os.Getenv("RATINGS_SENTINEL_PASSWORD"),
+	}
+
+	if addrs := os.Getenv("RATINGS_SENTINEL_ADDRS"); addrs != "" {
+		opts.Sentinel = strings.Split(addrs, ",")
+	}
+
+	return opts
+}
+
+// envInt reads the named environment variable as an int, returning fallback
+// when it is unset or not a valid integer.
+func envInt(name string, fallback int) int {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return fallback
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+
+	return i
 }
@@ -5,14 +5,20 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/logger"
 )
 
-func SetupApp() *fiber.App {
+// SetupApp wires the HTTP routes to the given App's handlers. The App is
+// expected to already hold live repository connections, constructed once via
+// NewApp, so that handlers reuse them instead of dialing their dependencies
+// on every request.
+func SetupApp(a *App) *fiber.App {
 	app := fiber.New()
 
 	app.Use(logger.New())
 
-	app.Get("/", Root)
-	app.Get("/ratings", Ratings)
-	app.Post("/ratings", AddRating)
+	app.Get("/", a.Root)
+	app.Get("/ratings", a.Ratings)
+	app.Post("/ratings", a.AddRating)
+	app.Get("/ratings/stream/:uuid", a.RatingsStream)
+	app.Get("/ratings/stats/:uuid", a.RatingsStats)
 
 	return app
 }
@@ -4,18 +4,34 @@
 package app_test
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/workshop-go/internal/app"
 )
 
+func newTestApp(t *testing.T) *fiber.App {
+	a, err := app.NewApp(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = a.Close(context.Background())
+	})
+
+	return app.SetupApp(a)
+}
+
 // the "GET /" endpoint returns a JSON with metadata including
 // the connection strings for the dependencies
 type responseType struct {
@@ -23,10 +39,10 @@ type responseType struct {
 }
 
 func TestRootRouteWithDependencies(t *testing.T) {
-	app := app.SetupApp()
+	srv := newTestApp(t)
 
 	req, _ := http.NewRequest("GET", "/", nil)
-	res, err := app.Test(req, -1)
+	res, err := srv.Test(req, -1)
 	require.NoError(t, err)
 
 	require.Equal(t, http.StatusOK, res.StatusCode)
@@ -45,6 +61,56 @@ func TestRootRouteWithDependencies(t *testing.T) {
 	matches(t, response.Connections.Lambda, `lambda-url.us-east-1.localhost.localstack.cloud:`)
 }
 
+// TestRatingsStats posts a rating while subscribed to the `/ratings/stats/:uuid`
+// SSE endpoint, and asserts the stream pushes a histogram reflecting it, proving
+// the endpoint reacts to the underlying Redis keyspace notification rather than
+// requiring the client to poll `GET /ratings`.
+func TestRatingsStats(t *testing.T) {
+	srv := newTestApp(t)
+
+	const uuid = "testcontainers-integration-testing"
+
+	req, err := http.NewRequest("GET", "/ratings/stats/"+uuid, nil)
+	require.NoError(t, err)
+
+	res, err := srv.Test(req, -1)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = res.Body.Close()
+	})
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	go func() {
+		// give the SSE handler time to start watching before the rating lands.
+		time.Sleep(500 * time.Millisecond)
+
+		body := []byte(fmt.Sprintf(`{"talkId":%q,"value":5}`, uuid))
+		postReq, err := http.NewRequest("POST", "/ratings", bytes.NewReader(body))
+		if err != nil {
+			return
+		}
+		postReq.Header.Add("Content-Type", "application/json")
+
+		_, _ = srv.Test(postReq, -1)
+	}()
+
+	reader := bufio.NewReader(res.Body)
+
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		assert.Contains(t, line, `"ratings"`)
+		break
+	}
+}
+
 func matches(t *testing.T, actual string, re string) {
 	matched, err := regexp.MatchString(re, actual)
 	require.NoError(t, err)
@@ -53,12 +119,12 @@ func matches(t *testing.T, actual string, re string) {
 }
 
 func TestRoutesWithDependencies(t *testing.T) {
-	app := app.SetupApp()
+	srv := newTestApp(t)
 
 	t.Run("GET /ratings", func(t *testing.T) {
 		req, err := http.NewRequest("GET", "/ratings?talkId=testcontainers-integration-testing", nil)
 		require.NoError(t, err)
-		res, err := app.Test(req, -1)
+		res, err := srv.Test(req, -1)
 		require.NoError(t, err)
 
 		// we are receiving a 200 because the ratings repository is started
@@ -74,7 +140,7 @@ func TestRoutesWithDependencies(t *testing.T) {
 		// we need to set the content type header because we are sending a body
 		req.Header.Add("Content-Type", "application/json")
 
-		res, err := app.Test(req, -1)
+		res, err := srv.Test(req, -1)
 		require.NoError(t, err)
 
 		// we are receiving a 200 because the ratings repository is started
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/testcontainers/workshop-go/internal/ratings"
+	"github.com/testcontainers/workshop-go/internal/ratings/keywatcher"
+)
+
+// watchTimeout bounds how long a single WatchKey call blocks before the SSE
+// handler sends a keep-alive comment and tries again.
+const watchTimeout = 30 * time.Second
+
+// statsDebounce bounds how long RatingsStats waits for further changes to
+// arrive after the first one, before recomputing and pushing the histogram.
+// It coalesces a burst of ratings landing within a short window (e.g. a talk
+// going viral) into a single push instead of one per rating.
+const statsDebounce = 250 * time.Millisecond
+
+// RatingsStream is the handler for the `GET /ratings/stream/:uuid` endpoint.
+// It upgrades the connection to Server-Sent Events and pushes a fresh
+// histogram, plus the stats from the configured stats backend, every time a new rating is
+// recorded for the talk, instead of requiring the client to poll `GET /ratings`.
+func (a *App) RatingsStream(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return handleError(c, http.StatusBadRequest, fmt.Errorf("uuid is required"))
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	ctx := context.Background()
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		lastSeen := time.Now()
+
+		for {
+			status, err := a.ratingsWatcher.WatchKey(ctx, uuid, lastSeen, watchTimeout)
+			if err != nil {
+				return
+			}
+
+			if status == keywatcher.Timeout {
+				if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+				continue
+			}
+
+			lastSeen = time.Now()
+
+			payload, err := a.ratingsStatsPayload(ctx, uuid)
+			if err != nil {
+				return
+			}
+
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", payload); err != nil {
+				return
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// RatingsStats is the handler for the `GET /ratings/stats/:uuid` endpoint.
+// Like RatingsStream, it upgrades the connection to Server-Sent Events and
+// pushes a fresh histogram plus the stats from the configured stats backend whenever a rating
+// is recorded for the talk, except that it debounces by statsDebounce first,
+// so that a burst of ratings arriving in quick succession is coalesced into a
+// single push instead of one per rating.
+func (a *App) RatingsStats(c *fiber.Ctx) error {
+	uuid := c.Params("uuid")
+	if uuid == "" {
+		return handleError(c, http.StatusBadRequest, fmt.Errorf("uuid is required"))
+	}
+
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	ctx := context.Background()
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		lastSeen := time.Now()
+
+		for {
+			newSeen, status, err := waitForDebouncedChange(ctx, a.ratingsWatcher, uuid, lastSeen)
+			if err != nil {
+				return
+			}
+			lastSeen = newSeen
+
+			if status == keywatcher.Timeout {
+				if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+				continue
+			}
+
+			payload, err := a.ratingsStatsPayload(ctx, uuid)
+			if err != nil {
+				return
+			}
+
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", payload); err != nil {
+				return
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// waitForDebouncedChange blocks like Watcher.WatchKey, but once a change is
+// observed it keeps watching for statsDebounce, restarting the window every
+// time another change lands, so that a burst of changes is reported as a
+// single Changed result once the talk's hash has settled.
+func waitForDebouncedChange(ctx context.Context, watcher *keywatcher.Watcher, uuid string, lastSeen time.Time) (time.Time, keywatcher.Status, error) {
+	status, err := watcher.WatchKey(ctx, uuid, lastSeen, watchTimeout)
+	if err != nil || status != keywatcher.Changed {
+		return lastSeen, status, err
+	}
+
+	for {
+		seen := time.Now()
+
+		status, err := watcher.WatchKey(ctx, uuid, seen, statsDebounce)
+		if err != nil {
+			return seen, keywatcher.NoChange, err
+		}
+		if status == keywatcher.Timeout {
+			return seen, keywatcher.Changed, nil
+		}
+	}
+}
+
+// ratingsStatsPayload builds the JSON payload sent over the SSE stream: the
+// raw histogram plus the aggregated stats, mirroring the shape of the
+// `GET /ratings` response.
+func (a *App) ratingsStatsPayload(ctx context.Context, uuid string) ([]byte, error) {
+	histogram, err := a.ratingsRepo.FindAllByUUID(ctx, uuid)
+	if err != nil && !errors.Is(err, ratings.ErrRatingNotFound) {
+		return nil, err
+	}
+
+	payload := fiber.Map{"ratings": histogram}
+	if stats, err := a.stats.GetStats(histogram); err == nil {
+		payload["stats"] = stats
+	}
+
+	return json.Marshal(payload)
+}
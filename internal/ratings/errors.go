@@ -0,0 +1,10 @@
+package ratings
+
+import "errors"
+
+// ErrRatingNotFound is returned when no rating hash exists for the requested
+// talk UUID, as opposed to a hash that exists but is empty.
+var ErrRatingNotFound = errors.New("ratings: rating not found")
+
+// ErrRepositoryUnavailable is returned when the repository could not reach Redis.
+var ErrRepositoryUnavailable = errors.New("ratings: repository unavailable")
@@ -0,0 +1,109 @@
+package ratings_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/testcontainers/testcontainers-go/network"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/testcontainers/workshop-go/internal/ratings"
+)
+
+// TestSentinelFailover boots a master, a replica and a Sentinel watching
+// them, then kills the master to prove that a Repository connected through
+// Sentinel keeps serving writes once Sentinel promotes the replica.
+func TestSentinelFailover(t *testing.T) {
+	ctx := context.Background()
+
+	net, err := network.New(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = net.Remove(ctx)
+	})
+
+	const masterAlias = "ratings-master"
+	const sentinelMaster = "mymaster"
+
+	masterC, err := redis.Run(ctx, "redis:7", network.WithNetwork([]string{masterAlias}, net))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = masterC.Terminate(ctx)
+	})
+
+	replicaC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:      "redis:7",
+			Networks:   []string{net.Name},
+			Cmd:        []string{"redis-server", "--replicaof", masterAlias, "6379"},
+			WaitingFor: wait.ForLog("MASTER <-> REPLICA sync: Finished with success"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = replicaC.Terminate(ctx)
+	})
+
+	sentinelConf := fmt.Sprintf(
+		"port 26379\nsentinel monitor %s %s 6379 1\nsentinel down-after-milliseconds %s 2000\nsentinel failover-timeout %s 5000\nsentinel parallel-syncs %s 1\n",
+		sentinelMaster, masterAlias, sentinelMaster, sentinelMaster, sentinelMaster,
+	)
+
+	sentinelC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7",
+			ExposedPorts: []string{"26379/tcp"},
+			Networks:     []string{net.Name},
+			Files: []testcontainers.ContainerFile{
+				{
+					Reader:            strings.NewReader(sentinelConf),
+					ContainerFilePath: "/usr/local/etc/redis/sentinel.conf",
+					FileMode:          0o644,
+				},
+			},
+			Cmd:        []string{"redis-server", "/usr/local/etc/redis/sentinel.conf", "--sentinel"},
+			WaitingFor: wait.ForLog("+monitor master " + sentinelMaster),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = sentinelC.Terminate(ctx)
+	})
+
+	sentinelHost, err := sentinelC.Host(ctx)
+	require.NoError(t, err)
+	sentinelPort, err := sentinelC.MappedPort(ctx, "26379/tcp")
+	require.NoError(t, err)
+
+	repo, err := ratings.NewRepositoryWithOptions(ctx, ratings.RepositoryOptions{
+		Sentinel:       []string{fmt.Sprintf("%s:%s", sentinelHost, sentinelPort.Port())},
+		SentinelMaster: sentinelMaster,
+	})
+	require.NoError(t, err)
+
+	rating := ratings.Rating{TalkUuid: "uuid-failover", Value: 5}
+
+	_, err = repo.Add(ctx, rating)
+	require.NoError(t, err)
+
+	// Killing the master forces Sentinel to detect the failure and promote
+	// the replica; the failover client should transparently reconnect to it.
+	require.NoError(t, masterC.Stop(ctx, nil))
+
+	require.Eventually(t, func() bool {
+		_, err := repo.Add(ctx, rating)
+		return err == nil
+	}, 90*time.Second, time.Second, "repo should keep serving writes once Sentinel promotes the replica")
+
+	values, err := repo.FindAllByUUID(ctx, rating.TalkUuid)
+	require.NoError(t, err)
+	assert.Equal(t, "2", values["5"])
+}
@@ -0,0 +1,206 @@
+// Package keywatcher lets HTTP handlers block until a talk's rating hash
+// changes in Redis, instead of polling it on a fixed interval. It is modelled
+// after GitLab Workhorse's goredis/keywatcher: a single process-wide
+// subscription to keyspace notifications fans out to per-key subscribers.
+package keywatcher
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Status is the outcome of a WatchKey call.
+type Status int
+
+const (
+	// NoChange is returned when the wait ended without observing a change,
+	// for example because the watcher was closed or the context was done.
+	NoChange Status = iota
+	// Changed is returned as soon as a change to the watched key is observed.
+	Changed
+	// Timeout is returned when the requested timeout elapsed with no change.
+	Timeout
+)
+
+// ErrClosed is returned by WatchKey when the Watcher has been closed.
+var ErrClosed = errors.New("keywatcher: watcher is closed")
+
+const keyspaceChannelPattern = "__keyspace@0__:ratings/*"
+
+// Watcher subscribes once to the "ratings/*" keyspace notifications and fans
+// out change events to whoever is watching a given talk UUID.
+type Watcher struct {
+	client *redis.Client
+	pubsub *redis.PubSub
+
+	groups sync.Map // map[string]*broadcaster
+
+	cancel context.CancelFunc
+	closed chan struct{}
+}
+
+// New creates a Watcher connected to the Redis instance at connStr. It sets
+// notify-keyspace-events to Kh (keyspace notifications for hash commands),
+// replacing any value configured before, since WatchKey relies on them.
+func New(ctx context.Context, connStr string) (*Watcher, error) {
+	options, err := redis.ParseURL(connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	client := redis.NewClient(options)
+
+	if err := client.ConfigSet(ctx, "notify-keyspace-events", "Kh").Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	pubsub := client.PSubscribe(ctx, keyspaceChannelPattern)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		client.Close()
+		return nil, err
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+
+	w := &Watcher{
+		client: client,
+		pubsub: pubsub,
+		cancel: cancel,
+		closed: make(chan struct{}),
+	}
+
+	go w.loop(watchCtx)
+
+	return w, nil
+}
+
+// loop routes keyspace notifications to the broadcaster of the talk they
+// belong to, until ctx is cancelled or the subscription channel is closed.
+func (w *Watcher) loop(ctx context.Context) {
+	defer close(w.closed)
+
+	ch := w.pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			uuid := strings.TrimPrefix(msg.Channel, "__keyspace@0__:ratings/")
+			if group, ok := w.groups.Load(uuid); ok {
+				group.(*broadcaster).notify()
+			}
+		}
+	}
+}
+
+// WatchKey blocks until the rating hash for uuid changes, timeout elapses,
+// ctx is done, or the Watcher is closed, whichever happens first. lastSeen
+// should be the time at which the caller last read the key, so that a change
+// racing with the call to WatchKey is not missed.
+func (w *Watcher) WatchKey(ctx context.Context, uuid string, lastSeen time.Time, timeout time.Duration) (Status, error) {
+	group := w.groupFor(uuid)
+
+	id, ch, missed := group.subscribe(lastSeen)
+	if missed {
+		group.unsubscribe(id)
+		return Changed, nil
+	}
+	defer group.unsubscribe(id)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-ch:
+		return Changed, nil
+	case <-timer.C:
+		return Timeout, nil
+	case <-w.closed:
+		return NoChange, ErrClosed
+	case <-ctx.Done():
+		return NoChange, ctx.Err()
+	}
+}
+
+// Close shuts down the keyspace subscription and wakes up every pending
+// WatchKey call with NoChange/ErrClosed.
+func (w *Watcher) Close() error {
+	w.cancel()
+	err := w.pubsub.Close()
+	<-w.closed
+
+	if cerr := w.client.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+func (w *Watcher) groupFor(uuid string) *broadcaster {
+	group, _ := w.groups.LoadOrStore(uuid, newBroadcaster())
+	return group.(*broadcaster)
+}
+
+// broadcaster fans out a single talk's change notifications to every
+// subscriber currently blocked in WatchKey for that talk.
+type broadcaster struct {
+	mu          sync.Mutex
+	subs        map[int]chan struct{}
+	nextID      int
+	lastChanged time.Time
+}
+
+func newBroadcaster() *broadcaster {
+	return &broadcaster{subs: make(map[int]chan struct{})}
+}
+
+// subscribe registers a new subscriber. If the key already changed after
+// lastSeen, it returns missed=true instead of a channel so the caller can
+// skip waiting entirely.
+func (b *broadcaster) subscribe(lastSeen time.Time) (id int, ch chan struct{}, missed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.lastChanged.After(lastSeen) {
+		return 0, nil, true
+	}
+
+	id = b.nextID
+	b.nextID++
+	ch = make(chan struct{}, 1)
+	b.subs[id] = ch
+
+	return id, ch, false
+}
+
+func (b *broadcaster) unsubscribe(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.subs, id)
+}
+
+func (b *broadcaster) notify() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastChanged = time.Now()
+
+	for _, ch := range b.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
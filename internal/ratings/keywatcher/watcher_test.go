@@ -0,0 +1,92 @@
+package keywatcher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	tcRedis "github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/testcontainers/workshop-go/internal/ratings/keywatcher"
+)
+
+func TestWatchKey(t *testing.T) {
+	ctx := context.Background()
+
+	redisContainer, err := tcRedis.RunContainer(ctx, testcontainers.WithImage("docker.io/redis:7"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connStr, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	watcher, err := keywatcher.New(ctx, connStr)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, watcher.Close())
+	})
+
+	options, err := redis.ParseURL(connStr)
+	require.NoError(t, err)
+	client := redis.NewClient(options)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	uid := "uuid12345"
+
+	t.Run("notified on change", func(t *testing.T) {
+		lastSeen := time.Now()
+
+		done := make(chan struct{})
+		var status keywatcher.Status
+		var watchErr error
+
+		go func() {
+			status, watchErr = watcher.WatchKey(ctx, uid, lastSeen, 5*time.Second)
+			close(done)
+		}()
+
+		// give WatchKey time to subscribe before the change happens.
+		time.Sleep(200 * time.Millisecond)
+		require.NoError(t, client.HIncrBy(ctx, "ratings/"+uid, "5", 1).Err())
+
+		<-done
+		require.NoError(t, watchErr)
+		assert.Equal(t, keywatcher.Changed, status)
+	})
+
+	t.Run("times out when nothing changes", func(t *testing.T) {
+		status, err := watcher.WatchKey(ctx, "uuid-no-changes", time.Now(), 300*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, keywatcher.Timeout, status)
+	})
+
+	t.Run("stops waiting after Close", func(t *testing.T) {
+		w, err := keywatcher.New(ctx, connStr)
+		require.NoError(t, err)
+
+		done := make(chan struct{})
+		var status keywatcher.Status
+		var watchErr error
+
+		go func() {
+			status, watchErr = w.WatchKey(ctx, "uuid-closing", time.Now(), 5*time.Second)
+			close(done)
+		}()
+
+		time.Sleep(200 * time.Millisecond)
+		require.NoError(t, w.Close())
+
+		<-done
+		assert.ErrorIs(t, watchErr, keywatcher.ErrClosed)
+		assert.Equal(t, keywatcher.NoChange, status)
+	})
+}
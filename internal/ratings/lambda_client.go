@@ -2,15 +2,22 @@ package ratings
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/testcontainers/workshop-go/internal/resilience"
 )
 
-// Repository is the interface that wraps the basic operations with the Redis store.
+// LambdaClient is a StatsComputer that delegates the aggregation to the
+// ratings Lambda function over HTTP.
 type LambdaClient struct {
 	client *http.Client
 	url    string
+	guard  *resilience.Guard
 }
 
 // NewLambdaClient creates a new client from the Lambda URL.
@@ -22,11 +29,15 @@ func NewLambdaClient(lambdaURL string) *LambdaClient {
 	return &LambdaClient{
 		client: &httpClient,
 		url:    lambdaURL,
+		guard: resilience.NewGuard(
+			resilience.DefaultBackoffPolicy(),
+			resilience.NewCircuitBreaker(defaultFailureThreshold, defaultResetTimeout),
+		),
 	}
 }
 
 // GetStats returns the stats for the given talk, obtained from a call to the Lambda function.
-// The payload is a JSON object with the following structure:
+// The histogram is marshalled into the RatingsEvent payload expected by the Lambda function:
 //
 //	{
 //	  "ratings": {
@@ -38,30 +49,46 @@ func NewLambdaClient(lambdaURL string) *LambdaClient {
 //	    "5": 60
 //	  }
 //	}
-//
-// The response from the Lambda function is a JSON object with the following structure:
-//
-//	{
-//	   "avg": 3.5,
-//	   "totalCount": 210,
-//	}
-func (c *LambdaClient) GetStats(histogram map[string]string) ([]byte, error) {
-	payload := `{"ratings": {`
-	for rating, count := range histogram {
-		// we are passing the count as an integer, so we don't need to quote it
-		payload += `"` + rating + `": ` + count + `,`
+func (c *LambdaClient) GetStats(histogram map[string]string) (Response, error) {
+	counts, err := histogramToCounts(histogram)
+	if err != nil {
+		return Response{}, err
 	}
 
-	if len(histogram) > 0 {
-		// remove the last comma onl for non-empty histograms
-		payload = payload[:len(payload)-1]
+	body, err := json.Marshal(RatingsEvent{Ratings: counts})
+	if err != nil {
+		return Response{}, fmt.Errorf("ratings: marshal ratings event: %w", err)
 	}
-	payload += "}}"
 
-	resp, err := c.client.Post(c.url, "application/json", bytes.NewBufferString(payload))
+	var stats Response
+
+	err = c.guard.Do(context.Background(), func() error {
+		resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("ratings: call lambda: %w", err)
+		}
+		defer resp.Body.Close()
+
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("ratings: read lambda response: %w", err)
+		}
+
+		if err := json.Unmarshal(respBody, &stats); err != nil {
+			return fmt.Errorf("ratings: unmarshal lambda response: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, err
+		return Response{}, err
 	}
 
-	return io.ReadAll(resp.Body)
+	return stats, nil
+}
+
+// Stats returns the retry/circuit-breaker metrics accumulated by this
+// client's calls to the Lambda function: attempts, failures, and breaker state.
+func (c *LambdaClient) Stats() resilience.Stats {
+	return c.guard.Stats()
 }
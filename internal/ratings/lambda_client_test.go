@@ -156,8 +156,8 @@ func TestGetStats(t *testing.T) {
 		t.Fatalf("failed to get stats: %s", err)
 	}
 
-	expected := `{"avg":3.3333333333333335,"totalCount":210}`
-	if string(stats) != expected {
-		t.Fatalf("expected %s, got %s", expected, string(stats))
+	expected := ratings.Response{Avg: 3.3333333333333335, TotalCount: 210}
+	if stats != expected {
+		t.Fatalf("expected %+v, got %+v", expected, stats)
 	}
 }
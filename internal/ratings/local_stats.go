@@ -0,0 +1,16 @@
+package ratings
+
+// LocalStatsComputer computes rating stats in-process, using the exact same
+// math as the Lambda function in lambda-go/main.go. It lets the app run
+// without LocalStack/Docker-in-Docker when STATS_BACKEND=local.
+type LocalStatsComputer struct{}
+
+// GetStats returns the stats for the given talk's rating histogram.
+func (LocalStatsComputer) GetStats(histogram map[string]string) (Response, error) {
+	counts, err := histogramToCounts(histogram)
+	if err != nil {
+		return Response{}, err
+	}
+
+	return ComputeStats(counts)
+}
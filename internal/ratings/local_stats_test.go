@@ -0,0 +1,41 @@
+package ratings_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/workshop-go/internal/ratings"
+)
+
+func TestLocalStatsComputer_GetStats(t *testing.T) {
+	computer := ratings.LocalStatsComputer{}
+
+	histogram := map[string]string{
+		"0": "10",
+		"1": "20",
+		"2": "30",
+		"3": "40",
+		"4": "50",
+		"5": "60",
+	}
+
+	stats, err := computer.GetStats(histogram)
+	require.NoError(t, err)
+	assert.Equal(t, ratings.Response{Avg: 3.3333333333333335, TotalCount: 210}, stats)
+}
+
+func TestLocalStatsComputer_GetStats_EmptyHistogram(t *testing.T) {
+	computer := ratings.LocalStatsComputer{}
+
+	stats, err := computer.GetStats(map[string]string{})
+	require.NoError(t, err)
+	assert.Equal(t, ratings.Response{}, stats)
+}
+
+func TestLocalStatsComputer_GetStats_InvalidCount(t *testing.T) {
+	computer := ratings.LocalStatsComputer{}
+
+	_, err := computer.GetStats(map[string]string{"0": "not-a-number"})
+	require.Error(t, err)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	tcRedis "github.com/testcontainers/testcontainers-go/modules/redis"
 	"github.com/testcontainers/workshop-go/internal/ratings"
+	"github.com/testcontainers/workshop-go/internal/resilience"
 )
 
 func TestNewRepository(t *testing.T) {
@@ -56,11 +57,28 @@ func TestNewRepository(t *testing.T) {
 			_, _ = repo.Add(ctx, rating)
 		}
 
-		values := repo.FindAllByUUID(ctx, takUUID)
+		values, err := repo.FindAllByUUID(ctx, takUUID)
+		require.NoError(t, err)
 		assert.Len(t, values, distribution)
 
 		for i := 0; i < distribution; i++ {
 			assert.Equal(t, fmt.Sprintf("%d", (max/distribution)), values[fmt.Sprintf("%d", i)])
 		}
 	})
+
+	t.Run("FindAllByUUID wraps ErrRatingNotFound for a missing UUID", func(t *testing.T) {
+		_, err := repo.FindAllByUUID(ctx, "uuid-does-not-exist")
+		require.ErrorIs(t, err, ratings.ErrRatingNotFound)
+	})
+
+	t.Run("Stats tracks successful calls", func(t *testing.T) {
+		before := repo.Stats()
+
+		_, err := repo.Add(ctx, ratings.Rating{TalkUuid: "uuid-stats", Value: 1})
+		require.NoError(t, err)
+
+		after := repo.Stats()
+		assert.Greater(t, after.Attempts, before.Attempts)
+		assert.Equal(t, resilience.Closed, after.BreakerState)
+	})
 }
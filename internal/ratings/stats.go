@@ -0,0 +1,63 @@
+package ratings
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// RatingsEvent is the payload LambdaClient sends to the Lambda function: a
+// histogram of rating value to how many times it was given, e.g. {"0": 10, "5": 60}.
+type RatingsEvent struct {
+	Ratings map[string]int `json:"ratings"`
+}
+
+// Response is the aggregate computed by a StatsComputer from a rating histogram.
+type Response struct {
+	Avg        float64 `json:"avg"`
+	TotalCount int     `json:"totalCount"`
+}
+
+// StatsComputer aggregates a talk's rating histogram, as returned by
+// Repository.FindAllByUUID, into a Response. LambdaClient and
+// LocalStatsComputer are the two implementations: the former calls out to the
+// Lambda function, the latter reuses the same math in-process.
+type StatsComputer interface {
+	GetStats(histogram map[string]string) (Response, error)
+}
+
+// ComputeStats contains the math shared by every StatsComputer: the average
+// rating and the total number of ratings collected.
+func ComputeStats(counts map[string]int) (Response, error) {
+	var totalCount, sum int
+	for rating, count := range counts {
+		r, err := strconv.Atoi(rating)
+		if err != nil {
+			return Response{}, fmt.Errorf("ratings: invalid rating key %q: %w", rating, err)
+		}
+
+		totalCount += count
+		sum += count * r
+	}
+
+	var avg float64
+	if totalCount > 0 {
+		avg = float64(sum) / float64(totalCount)
+	}
+
+	return Response{Avg: avg, TotalCount: totalCount}, nil
+}
+
+// histogramToCounts parses the string counts returned by HGETALL into ints.
+func histogramToCounts(histogram map[string]string) (map[string]int, error) {
+	counts := make(map[string]int, len(histogram))
+	for rating, count := range histogram {
+		c, err := strconv.Atoi(count)
+		if err != nil {
+			return nil, fmt.Errorf("ratings: invalid rating count %q for %q: %w", count, rating, err)
+		}
+
+		counts[rating] = c
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,7 @@
+package ratings
+
+// Rating is a struct that represents a single rating submitted for a talk.
+type Rating struct {
+	TalkUuid string `json:"talkId"`
+	Value    int64  `json:"value"`
+}
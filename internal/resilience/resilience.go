@@ -0,0 +1,303 @@
+// Package resilience provides small, dependency-free building blocks for
+// calling flaky services: exponential backoff with jitter, and a half-open
+// circuit breaker. Guard combines the two so callers get retries, circuit
+// breaking, and metrics from a single Do call.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Guard.Do when the circuit breaker is open and
+// the call was not attempted at all.
+var ErrCircuitOpen = errors.New("resilience: circuit breaker is open")
+
+// BackoffPolicy configures an exponential backoff with jitter.
+type BackoffPolicy struct {
+	// InitialDelay is the delay before the first retry.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between retries.
+	MaxDelay time.Duration
+	// MaxAttempts is the maximum number of calls to fn, including the first one.
+	MaxAttempts int
+	// Jitter is the fraction (0..1) of each delay that is randomized, to
+	// avoid thundering-herd retries across clients.
+	Jitter float64
+}
+
+// DefaultBackoffPolicy is a reasonable default for short-lived dependency
+// calls: up to 5 attempts, starting at 100ms and capping at 2s.
+func DefaultBackoffPolicy() BackoffPolicy {
+	return BackoffPolicy{
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     2 * time.Second,
+		MaxAttempts:  5,
+		Jitter:       0.2,
+	}
+}
+
+// RetryOption configures the BackoffPolicy used by Retry.
+type RetryOption func(*BackoffPolicy)
+
+// WithMaxAttempts overrides the number of times Retry calls fn, including the
+// first call.
+func WithMaxAttempts(maxAttempts int) RetryOption {
+	return func(p *BackoffPolicy) { p.MaxAttempts = maxAttempts }
+}
+
+// WithBaseDelay overrides the delay Retry waits before the first retry.
+func WithBaseDelay(d time.Duration) RetryOption {
+	return func(p *BackoffPolicy) { p.InitialDelay = d }
+}
+
+// WithMaxDelay overrides the cap Retry applies to the delay between retries.
+func WithMaxDelay(d time.Duration) RetryOption {
+	return func(p *BackoffPolicy) { p.MaxDelay = d }
+}
+
+// Retry calls fn until it succeeds, ctx is done, or the policy's MaxAttempts
+// is reached, waiting an exponentially increasing, jittered delay between
+// attempts. It is meant for one-off operations like dialing a dependency at
+// startup, where there is no ongoing circuit breaker to track; for repeated
+// calls against an already-established connection, use a Guard instead.
+//
+// If retrying stops because ctx was cancelled or its deadline passed, Retry
+// returns ctx.Err() rather than the last error from fn.
+func Retry(ctx context.Context, fn func() error, opts ...RetryOption) error {
+	policy := DefaultBackoffPolicy()
+	for _, opt := range opts {
+		opt(&policy)
+	}
+
+	_, err := policy.retry(ctx, fn)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+	}
+
+	return err
+}
+
+// retry calls fn until it succeeds, ctx is done, or MaxAttempts is reached,
+// waiting an exponentially increasing, jittered delay between attempts.
+// attempted is the number of times fn was called.
+func (p BackoffPolicy) retry(ctx context.Context, fn func() error) (attempted int, err error) {
+	delay := p.InitialDelay
+
+	maxAttempts := p.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		attempted = attempt
+		err = fn()
+		if err == nil {
+			return attempted, nil
+		}
+
+		if attempt == maxAttempts {
+			return attempted, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempted, ctx.Err()
+		case <-time.After(p.jittered(delay)):
+		}
+
+		delay *= 2
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+	}
+
+	return attempted, err
+}
+
+func (p BackoffPolicy) jittered(d time.Duration) time.Duration {
+	if p.Jitter <= 0 || d <= 0 {
+		return d
+	}
+
+	delta := time.Duration(float64(d) * p.Jitter)
+	if delta <= 0 {
+		return d
+	}
+
+	return d - delta + time.Duration(rand.Int63n(int64(2*delta)+1))
+}
+
+// State is the state of a CircuitBreaker.
+type State int
+
+const (
+	// Closed is the normal state: calls go through.
+	Closed State = iota
+	// Open rejects calls without attempting them, until resetTimeout elapses.
+	Open
+	// HalfOpen allows a single trial call through to decide whether to
+	// close the circuit again or re-open it.
+	HalfOpen
+)
+
+// String implements fmt.Stringer.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker is a simple half-open circuit breaker: it opens after
+// failureThreshold consecutive failures, and after resetTimeout allows a
+// single trial call through before deciding whether to close again.
+type CircuitBreaker struct {
+	failureThreshold int
+	resetTimeout     time.Duration
+
+	mu            sync.Mutex
+	state         State
+	failures      int
+	openedAt      time.Time
+	trialInFlight bool
+}
+
+// NewCircuitBreaker creates a CircuitBreaker that opens after
+// failureThreshold consecutive failures and stays open for resetTimeout.
+func NewCircuitBreaker(failureThreshold int, resetTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		resetTimeout:     resetTimeout,
+	}
+}
+
+// allow reports whether a call should be attempted, transitioning Open to
+// HalfOpen once resetTimeout has elapsed. While HalfOpen, only a single
+// trial call is let through at a time: concurrent callers are refused until
+// that trial calls recordSuccess or recordFailure, so a still-broken backend
+// is not hit by every in-flight request at once.
+func (cb *CircuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case Closed:
+		return true
+	case HalfOpen:
+		if cb.trialInFlight {
+			return false
+		}
+	default: // Open
+		if time.Since(cb.openedAt) < cb.resetTimeout {
+			return false
+		}
+		cb.state = HalfOpen
+	}
+
+	cb.trialInFlight = true
+	return true
+}
+
+func (cb *CircuitBreaker) recordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures = 0
+	cb.state = Closed
+	cb.trialInFlight = false
+}
+
+func (cb *CircuitBreaker) recordFailure() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.failures++
+	cb.trialInFlight = false
+
+	if cb.state == HalfOpen || cb.failures >= cb.failureThreshold {
+		cb.state = Open
+		cb.openedAt = time.Now()
+	}
+}
+
+// State returns the current state of the breaker.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.state
+}
+
+// Stats is a snapshot of the calls a Guard has made, for tests and
+// diagnostics.
+type Stats struct {
+	Attempts     int
+	Failures     int
+	BreakerState State
+}
+
+// Guard combines a BackoffPolicy and a CircuitBreaker around a call, and
+// keeps running totals so callers can assert on retry behavior via Stats.
+type Guard struct {
+	backoff BackoffPolicy
+	breaker *CircuitBreaker
+
+	mu       sync.Mutex
+	attempts int
+	failures int
+}
+
+// NewGuard creates a Guard from a backoff policy and a circuit breaker.
+func NewGuard(backoff BackoffPolicy, breaker *CircuitBreaker) *Guard {
+	return &Guard{backoff: backoff, breaker: breaker}
+}
+
+// Do runs fn, retrying it with backoff on failure, short-circuiting entirely
+// with ErrCircuitOpen while the breaker is open.
+func (g *Guard) Do(ctx context.Context, fn func() error) error {
+	if !g.breaker.allow() {
+		return ErrCircuitOpen
+	}
+
+	attempted, err := g.backoff.retry(ctx, fn)
+
+	g.mu.Lock()
+	g.attempts += attempted
+	if err != nil {
+		g.failures++
+	}
+	g.mu.Unlock()
+
+	if err != nil {
+		g.breaker.recordFailure()
+		return err
+	}
+
+	g.breaker.recordSuccess()
+	return nil
+}
+
+// Stats returns a snapshot of the attempts, failures, and breaker state
+// observed so far.
+func (g *Guard) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return Stats{
+		Attempts:     g.attempts,
+		Failures:     g.failures,
+		BreakerState: g.breaker.State(),
+	}
+}
@@ -0,0 +1,197 @@
+package resilience_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/workshop-go/internal/resilience"
+)
+
+var errBoom = errors.New("boom")
+
+func testBackoff() resilience.BackoffPolicy {
+	return resilience.BackoffPolicy{
+		InitialDelay: time.Millisecond,
+		MaxDelay:     5 * time.Millisecond,
+		MaxAttempts:  3,
+		Jitter:       0,
+	}
+}
+
+func TestGuard_RetriesUntilSuccess(t *testing.T) {
+	guard := resilience.NewGuard(testBackoff(), resilience.NewCircuitBreaker(10, time.Second))
+
+	calls := 0
+	err := guard.Do(context.Background(), func() error {
+		calls++
+		if calls < 2 {
+			return errBoom
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	stats := guard.Stats()
+	assert.Equal(t, 2, stats.Attempts)
+	assert.Equal(t, 0, stats.Failures)
+	assert.Equal(t, resilience.Closed, stats.BreakerState)
+}
+
+func TestGuard_GivesUpAfterMaxAttempts(t *testing.T) {
+	guard := resilience.NewGuard(testBackoff(), resilience.NewCircuitBreaker(10, time.Second))
+
+	calls := 0
+	err := guard.Do(context.Background(), func() error {
+		calls++
+		return errBoom
+	})
+
+	require.ErrorIs(t, err, errBoom)
+	assert.Equal(t, 3, calls)
+
+	stats := guard.Stats()
+	assert.Equal(t, 3, stats.Attempts)
+	assert.Equal(t, 1, stats.Failures)
+}
+
+func TestGuard_OpensCircuitAfterThreshold(t *testing.T) {
+	guard := resilience.NewGuard(testBackoff(), resilience.NewCircuitBreaker(2, time.Hour))
+
+	for i := 0; i < 2; i++ {
+		err := guard.Do(context.Background(), func() error { return errBoom })
+		require.ErrorIs(t, err, errBoom)
+	}
+
+	assert.Equal(t, resilience.Open, guard.Stats().BreakerState)
+
+	calls := 0
+	err := guard.Do(context.Background(), func() error {
+		calls++
+		return nil
+	})
+
+	require.ErrorIs(t, err, resilience.ErrCircuitOpen)
+	assert.Equal(t, 0, calls, "the guarded function must not run while the breaker is open")
+}
+
+func TestGuard_HalfOpenRecoversOnSuccess(t *testing.T) {
+	breaker := resilience.NewCircuitBreaker(1, 10*time.Millisecond)
+	guard := resilience.NewGuard(testBackoff(), breaker)
+
+	require.ErrorIs(t, guard.Do(context.Background(), func() error { return errBoom }), errBoom)
+	assert.Equal(t, resilience.Open, breaker.State())
+
+	time.Sleep(20 * time.Millisecond)
+
+	require.NoError(t, guard.Do(context.Background(), func() error { return nil }))
+	assert.Equal(t, resilience.Closed, breaker.State())
+}
+
+func TestGuard_HalfOpenAllowsOnlyOneConcurrentTrial(t *testing.T) {
+	breaker := resilience.NewCircuitBreaker(1, 10*time.Millisecond)
+	guard := resilience.NewGuard(testBackoff(), breaker)
+
+	require.ErrorIs(t, guard.Do(context.Background(), func() error { return errBoom }), errBoom)
+	assert.Equal(t, resilience.Open, breaker.State())
+
+	time.Sleep(20 * time.Millisecond)
+
+	const concurrency = 10
+
+	release := make(chan struct{})
+	var admitted int32
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+
+			err := guard.Do(context.Background(), func() error {
+				atomic.AddInt32(&admitted, 1)
+				<-release
+				return nil
+			})
+			if err != nil {
+				require.ErrorIs(t, err, resilience.ErrCircuitOpen)
+			}
+		}()
+	}
+
+	// give every goroutine a chance to reach guard.Do before letting the
+	// single admitted trial finish.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&admitted), "only one trial call should run while the breaker is half-open")
+	assert.Equal(t, resilience.Closed, breaker.State())
+}
+
+func TestRetry_RetriesUntilSuccess(t *testing.T) {
+	calls := 0
+	err := resilience.Retry(context.Background(), func() error {
+		calls++
+		if calls < 3 {
+			return errBoom
+		}
+		return nil
+	}, resilience.WithMaxAttempts(5), resilience.WithBaseDelay(time.Millisecond), resilience.WithMaxDelay(5*time.Millisecond))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	err := resilience.Retry(context.Background(), func() error {
+		calls++
+		return errBoom
+	}, resilience.WithMaxAttempts(3), resilience.WithBaseDelay(time.Millisecond), resilience.WithMaxDelay(5*time.Millisecond))
+
+	require.ErrorIs(t, err, errBoom)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetry_HonorsContextCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := resilience.Retry(ctx, func() error {
+		calls++
+		return errBoom
+	}, resilience.WithMaxAttempts(5), resilience.WithBaseDelay(time.Second), resilience.WithMaxDelay(time.Second))
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestGuard_HonorsContextCancellation(t *testing.T) {
+	guard := resilience.NewGuard(resilience.BackoffPolicy{
+		InitialDelay: time.Second,
+		MaxDelay:     time.Second,
+		MaxAttempts:  5,
+	}, resilience.NewCircuitBreaker(10, time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := guard.Do(ctx, func() error {
+		calls++
+		return errBoom
+	})
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
@@ -4,25 +4,36 @@ import (
 	"context"
 
 	"github.com/testcontainers/workshop-go/internal/ratings"
+	"github.com/testcontainers/workshop-go/internal/resilience"
 	"github.com/twmb/franz-go/pkg/kgo"
 )
 
 const RatingsTopic = "ratings"
 
 // Repository is the interface that wraps the basic operations with the broker store.
+// It only produces to RatingsTopic; materializing the ratings it carries into
+// the ratings store is the responsibility of a Consumer.
 type Repository struct {
 	client *kgo.Client
 }
 
-// NewStream creates a new repository. It will receive a context and the connection string for the broker.
+// NewStream creates a new repository. It will receive a context and the
+// connection string for the broker. The initial broker metadata check is
+// retried with backoff, since the very first request after startup can race
+// the broker container becoming ready.
 func NewStream(ctx context.Context, connStr string) (*Repository, error) {
 	cli, err := kgo.NewClient(
 		kgo.SeedBrokers(connStr),
-		kgo.ConsumeTopics(RatingsTopic),
 		kgo.AllowAutoTopicCreation(),
 	)
 	if err != nil {
-		// You probably want to retry here
+		return nil, err
+	}
+
+	if err := resilience.Retry(ctx, func() error {
+		return cli.Ping(ctx)
+	}); err != nil {
+		cli.Close()
 		return nil, err
 	}
 
@@ -33,7 +44,12 @@ func NewStream(ctx context.Context, connStr string) (*Repository, error) {
 // when the record is produced. It will notifiy the caller if the operation errored or
 // if the context was cancelled.
 func (r *Repository) SendRating(ctx context.Context, rating ratings.Rating, produceCallback func() error) error {
-	record := &kgo.Record{Topic: RatingsTopic, Value: []byte("test")}
+	value, err := encodeRatingEvent(rating)
+	if err != nil {
+		return err
+	}
+
+	record := &kgo.Record{Topic: RatingsTopic, Key: []byte(rating.TalkUuid), Value: value}
 
 	errChan := make(chan error, 1)
 
@@ -61,3 +77,8 @@ func (r *Repository) SendRating(ctx context.Context, rating ratings.Rating, prod
 		return err
 	}
 }
+
+// Close closes the underlying Kafka client, flushing any buffered records.
+func (r *Repository) Close() {
+	r.client.Close()
+}
@@ -0,0 +1,72 @@
+package streams
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/testcontainers/workshop-go/internal/ratings"
+)
+
+const (
+	// cloudEventsSpecVersion is the CloudEvents spec version every envelope
+	// produced by this package is stamped with.
+	cloudEventsSpecVersion = "1.0"
+	// cloudEventSource identifies this service as the CloudEvents source.
+	cloudEventSource = "workshop-go/ratings"
+	// ratingSubmittedType is the CloudEvents type for a rating submission.
+	ratingSubmittedType = "com.testcontainers.workshop.rating.submitted"
+)
+
+// cloudEvent is the structured-mode CloudEvents v1.0 JSON envelope used to
+// carry a rating on RatingsTopic.
+type cloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	ID              string         `json:"id"`
+	Source          string         `json:"source"`
+	Type            string         `json:"type"`
+	Time            time.Time      `json:"time"`
+	DataContentType string         `json:"datacontenttype"`
+	Data            ratings.Rating `json:"data"`
+}
+
+// encodeRatingEvent wraps rating in a CloudEvents v1.0 structured JSON
+// envelope of type ratingSubmittedType, ready to be used as a Kafka record value.
+func encodeRatingEvent(rating ratings.Rating) ([]byte, error) {
+	event := cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              uuid.NewString(),
+		Source:          cloudEventSource,
+		Type:            ratingSubmittedType,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            rating,
+	}
+
+	value, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("streams: marshal cloud event: %w", err)
+	}
+
+	return value, nil
+}
+
+// decodeRatingEvent unwraps a rating from a CloudEvents v1.0 structured JSON
+// envelope, rejecting envelopes with a missing specversion or a type other
+// than ratingSubmittedType.
+func decodeRatingEvent(value []byte) (ratings.Rating, error) {
+	var event cloudEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		return ratings.Rating{}, fmt.Errorf("streams: unmarshal cloud event: %w", err)
+	}
+
+	if event.SpecVersion == "" {
+		return ratings.Rating{}, fmt.Errorf("streams: cloud event missing specversion")
+	}
+	if event.Type != ratingSubmittedType {
+		return ratings.Rating{}, fmt.Errorf("streams: unexpected cloud event type %q", event.Type)
+	}
+
+	return event.Data, nil
+}
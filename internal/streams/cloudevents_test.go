@@ -0,0 +1,62 @@
+package streams
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/workshop-go/internal/ratings"
+)
+
+func TestEncodeDecodeRatingEvent(t *testing.T) {
+	rating := ratings.Rating{TalkUuid: "uuid12345", Value: 5}
+
+	value, err := encodeRatingEvent(rating)
+	require.NoError(t, err)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(value, &raw))
+	assert.Equal(t, cloudEventsSpecVersion, raw["specversion"])
+	assert.Equal(t, ratingSubmittedType, raw["type"])
+	assert.Equal(t, cloudEventSource, raw["source"])
+	assert.Equal(t, "application/json", raw["datacontenttype"])
+	assert.NotEmpty(t, raw["id"])
+
+	decoded, err := decodeRatingEvent(value)
+	require.NoError(t, err)
+	assert.Equal(t, rating, decoded)
+}
+
+func TestDecodeRatingEventRejectsUnknownType(t *testing.T) {
+	event := cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              "some-id",
+		Source:          cloudEventSource,
+		Type:            "com.testcontainers.workshop.rating.deleted",
+		DataContentType: "application/json",
+		Data:            ratings.Rating{TalkUuid: "uuid12345", Value: 5},
+	}
+
+	value, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	_, err = decodeRatingEvent(value)
+	require.Error(t, err)
+}
+
+func TestDecodeRatingEventRejectsMissingSpecVersion(t *testing.T) {
+	event := cloudEvent{
+		ID:              "some-id",
+		Source:          cloudEventSource,
+		Type:            ratingSubmittedType,
+		DataContentType: "application/json",
+		Data:            ratings.Rating{TalkUuid: "uuid12345", Value: 5},
+	}
+
+	value, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	_, err = decodeRatingEvent(value)
+	require.Error(t, err)
+}
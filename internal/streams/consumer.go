@@ -0,0 +1,125 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/testcontainers/workshop-go/internal/ratings"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// DefaultConsumerGroup is the consumer group used to materialize RatingsTopic
+// into the ratings store when no other group is configured.
+const DefaultConsumerGroup = "workshop-go-ratings"
+
+// Consumer runs a background PollFetches loop against RatingsTopic,
+// decoding each record and applying it to a ratings.Repository, so that the
+// topic, rather than a request-goroutine side effect, is what keeps the
+// ratings store up to date.
+type Consumer struct {
+	client      *kgo.Client
+	ratingsRepo *ratings.Repository
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewConsumer creates a new Consumer that will poll RatingsTopic on the
+// broker at connStr as part of groupID, applying decoded ratings to
+// ratingsRepo.
+func NewConsumer(connStr string, groupID string, ratingsRepo *ratings.Repository) (*Consumer, error) {
+	cli, err := kgo.NewClient(
+		kgo.SeedBrokers(connStr),
+		kgo.ConsumeTopics(RatingsTopic),
+		kgo.ConsumerGroup(groupID),
+		kgo.AllowAutoTopicCreation(),
+		kgo.DisableAutoCommit(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("streams: new consumer: %w", err)
+	}
+
+	return &Consumer{client: cli, ratingsRepo: ratingsRepo}, nil
+}
+
+// Start launches the PollFetches loop in a background goroutine, returning
+// immediately. The loop runs until ctx is cancelled or Stop is called.
+func (c *Consumer) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go c.loop(ctx)
+}
+
+// loop polls for new records, applies each one to the ratings repository,
+// and commits offsets once the batch has been handled.
+func (c *Consumer) loop(ctx context.Context) {
+	defer close(c.done)
+
+	for {
+		fetches := c.client.PollFetches(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		fetches.EachError(func(topic string, partition int32, err error) {
+			log.Printf("streams: fetch error on %s/%d: %s", topic, partition, err)
+		})
+
+		var applied []*kgo.Record
+
+		fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+			for _, record := range p.Records {
+				if err := c.applyRecord(ctx, record); err != nil {
+					log.Printf("streams: apply record at %s/%d@%d: %s", record.Topic, record.Partition, record.Offset, err)
+					// Stop at the first failure in this partition: committing
+					// past it would advance the offset beyond a rating that
+					// was never applied, losing it for good. Leaving the
+					// offset where it is means this record, and anything
+					// after it, is redelivered on the next poll.
+					return
+				}
+
+				applied = append(applied, record)
+			}
+		})
+
+		if len(applied) == 0 {
+			continue
+		}
+
+		if err := c.client.CommitRecords(ctx, applied...); err != nil {
+			log.Printf("streams: commit offsets: %s", err)
+		}
+	}
+}
+
+// applyRecord decodes a rating record and applies it to the ratings
+// repository, deduping on the record's topic/partition/offset so that
+// at-least-once redelivery from the broker never double-counts a rating.
+func (c *Consumer) applyRecord(ctx context.Context, record *kgo.Record) error {
+	rating, err := decodeRatingEvent(record.Value)
+	if err != nil {
+		return fmt.Errorf("decode rating: %w", err)
+	}
+
+	dedupeKey := fmt.Sprintf("%s/%d/%d", record.Topic, record.Partition, record.Offset)
+
+	_, err = c.ratingsRepo.AddOnce(ctx, rating, dedupeKey)
+	return err
+}
+
+// Stop cancels the PollFetches loop, waits for it to exit, and closes the
+// underlying Kafka client.
+func (c *Consumer) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	if c.done != nil {
+		<-c.done
+	}
+
+	c.client.Close()
+}
@@ -0,0 +1,80 @@
+package streams_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	tcRedis "github.com/testcontainers/testcontainers-go/modules/redis"
+	"github.com/testcontainers/testcontainers-go/modules/redpanda"
+	"github.com/testcontainers/workshop-go/internal/ratings"
+	"github.com/testcontainers/workshop-go/internal/streams"
+)
+
+func TestConsumer(t *testing.T) {
+	ctx := context.Background()
+
+	redpandaC, err := redpanda.RunContainer(
+		ctx,
+		testcontainers.WithImage("docker.redpanda.com/redpandadata/redpanda:v23.1.7"),
+		redpanda.WithAutoCreateTopics(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := redpandaC.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	seedBroker, err := redpandaC.KafkaSeedBroker(ctx)
+	require.NoError(t, err)
+
+	redisContainer, err := tcRedis.RunContainer(ctx, testcontainers.WithImage("docker.io/redis:6-alpine"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Fatalf("failed to terminate container: %s", err)
+		}
+	})
+
+	connStr, err := redisContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	ratingsRepo, err := ratings.NewRepository(ctx, connStr)
+	require.NoError(t, err)
+
+	producer, err := streams.NewStream(ctx, seedBroker)
+	require.NoError(t, err)
+
+	consumer, err := streams.NewConsumer(seedBroker, "test-consumer-group", ratingsRepo)
+	require.NoError(t, err)
+	consumer.Start(ctx)
+	t.Cleanup(consumer.Stop)
+
+	talkUUID := uuid.NewString()
+	const count = 10
+
+	for i := 0; i < count; i++ {
+		rating := ratings.Rating{TalkUuid: talkUUID, Value: 5}
+
+		err := producer.SendRating(ctx, rating, func() error { return nil })
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool {
+		values, err := ratingsRepo.FindAllByUUID(ctx, talkUUID)
+		if err != nil {
+			return false
+		}
+
+		return values["5"] == "10"
+	}, 30*time.Second, 100*time.Millisecond)
+
+	values, err := ratingsRepo.FindAllByUUID(ctx, talkUUID)
+	require.NoError(t, err)
+	assert.Equal(t, "10", values["5"])
+}
@@ -0,0 +1,10 @@
+package talks
+
+import "errors"
+
+// ErrTalkNotFound is returned when no talk matches the requested UUID.
+var ErrTalkNotFound = errors.New("talks: talk not found")
+
+// ErrRepositoryUnavailable is returned when the repository could not reach
+// PostgreSQL, as opposed to the query itself failing.
+var ErrRepositoryUnavailable = errors.New("talks: repository unavailable")
@@ -0,0 +1,82 @@
+package talks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/testcontainers/workshop-go/internal/resilience"
+)
+
+// Repository is the interface that wraps the basic operations with the PostgreSQL store.
+type Repository struct {
+	conn *pgx.Conn
+}
+
+// NewRepository creates a new repository. It will receive a context and the
+// PostgreSQL connection string. The initial connection is retried with
+// backoff, since the very first request after startup can race the database
+// container becoming ready.
+func NewRepository(ctx context.Context, connStr string) (*Repository, error) {
+	var conn *pgx.Conn
+
+	err := resilience.Retry(ctx, func() error {
+		c, err := pgx.Connect(ctx, connStr)
+		if err != nil {
+			return err
+		}
+
+		conn = c
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("talks: connect %q: %w: %w", connStr, ErrRepositoryUnavailable, err)
+	}
+
+	return &Repository{
+		conn: conn,
+	}, nil
+}
+
+// Create creates a new talk in the database.
+// It uses value semantics at the method receiver to avoid mutating the original repository.
+// It uses pointer semantics at the talk parameter to avoid copying the struct, modifying it and returning it.
+func (r Repository) Create(ctx context.Context, talk *Talk) error {
+	query := "INSERT INTO talks (uuid, title) VALUES ($1, $2) RETURNING id"
+
+	if err := r.conn.QueryRow(ctx, query, talk.UUID, talk.Title).Scan(&talk.ID); err != nil {
+		return fmt.Errorf("talks: create %q: %w", talk.Title, err)
+	}
+
+	return nil
+}
+
+// GetByUUID retrieves a talk from the database by its UUID. It returns
+// ErrTalkNotFound, wrapped, when no talk matches uid.
+func (r Repository) GetByUUID(ctx context.Context, uid string) (Talk, error) {
+	query := "SELECT id, uuid, title FROM talks WHERE uuid = $1"
+
+	var talk Talk
+	err := r.conn.QueryRow(ctx, query, uid).Scan(&talk.ID, &talk.UUID, &talk.Title)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Talk{}, fmt.Errorf("talks: get by uuid %q: %w", uid, ErrTalkNotFound)
+		}
+
+		return Talk{}, fmt.Errorf("talks: get by uuid %q: %w", uid, err)
+	}
+
+	return talk, nil
+}
+
+// Exists reports whether a talk with the given UUID exists.
+func (r Repository) Exists(ctx context.Context, uid string) bool {
+	_, err := r.GetByUUID(ctx, uid)
+	return err == nil
+}
+
+// Close closes the underlying PostgreSQL connection.
+func (r Repository) Close(ctx context.Context) error {
+	return r.conn.Close(ctx)
+}
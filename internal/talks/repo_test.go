@@ -79,4 +79,11 @@ func TestNewRepository(t *testing.T) {
 		found := talksRepo.Exists(ctx, uid)
 		require.False(t, found)
 	})
+
+	t.Run("GetByUUID wraps ErrTalkNotFound for a missing UUID", func(t *testing.T) {
+		uid := uuid.NewString()
+
+		_, err := talksRepo.GetByUUID(ctx, uid)
+		require.ErrorIs(t, err, talks.ErrTalkNotFound)
+	})
 }
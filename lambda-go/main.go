@@ -3,26 +3,12 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/testcontainers/workshop-go/internal/ratings"
 )
 
-type RatingsEvent struct {
-	Ratings map[string]int `json:"ratings"`
-}
-
-type Response struct {
-	Avg        float64 `json:"avg"`
-	TotalCount int     `json:"totalCount"`
-}
-
-var emptyResponse = Response{
-	Avg:        0,
-	TotalCount: 0,
-}
-
 // HandleStats returns the stats for the given talk, obtained from a call to the Lambda function.
 // The payload is a JSON object with the following structure:
 //
@@ -43,37 +29,16 @@ var emptyResponse = Response{
 //	   "avg": 3.5,
 //	   "totalCount": 210,
 //	}
-func HandleStats(event events.APIGatewayProxyRequest) (Response, error) {
-	ratingsEvent := RatingsEvent{}
-	err := json.Unmarshal([]byte(event.Body), &ratingsEvent)
-	if err != nil {
-		return emptyResponse, fmt.Errorf("failed to unmarshal ratings event: %s", err)
-	}
-
-	var totalCount int
-	var sum int
-	for rating, count := range ratingsEvent.Ratings {
-		totalCount += count
-
-		r, err := strconv.Atoi(rating)
-		if err != nil {
-			return emptyResponse, fmt.Errorf("failed to convert rating %s to int: %s", rating, err)
-		}
-
-		sum += count * r
-	}
-
-	var avg float64
-	if totalCount > 0 {
-		avg = float64(sum) / float64(totalCount)
-	}
-
-	resp := Response{
-		Avg:        avg,
-		TotalCount: totalCount,
+//
+// The aggregation itself lives in internal/ratings so that LocalStatsComputer
+// can reuse it without going through Lambda/LocalStack.
+func HandleStats(event events.APIGatewayProxyRequest) (ratings.Response, error) {
+	ratingsEvent := ratings.RatingsEvent{}
+	if err := json.Unmarshal([]byte(event.Body), &ratingsEvent); err != nil {
+		return ratings.Response{}, fmt.Errorf("failed to unmarshal ratings event: %s", err)
 	}
 
-	return resp, nil
+	return ratings.ComputeStats(ratingsEvent.Ratings)
 }
 
 func main() {
@@ -1,11 +1,43 @@
 package main
 
 import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/testcontainers/workshop-go/internal/app"
 )
 
 func main() {
-	app := app.SetupApp()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	a, err := app.NewApp(ctx)
+	if err != nil {
+		log.Fatalf("failed to initialize app: %s", err)
+	}
+
+	srv := app.SetupApp(a)
+
+	go func() {
+		if err := srv.Listen(":8080"); err != nil {
+			log.Printf("server stopped: %s", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := srv.ShutdownWithContext(shutdownCtx); err != nil {
+		log.Printf("failed to shut down server: %s", err)
+	}
 
-	app.Listen(":8080")
+	if err := a.Close(shutdownCtx); err != nil {
+		log.Printf("failed to close app: %s", err)
+	}
 }